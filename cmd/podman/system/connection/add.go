@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/containers/common/pkg/completion"
@@ -13,6 +14,7 @@ import (
 	"github.com/containers/common/pkg/ssh"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/cmd/podman/system"
+	"github.com/containers/storage/pkg/homedir"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -28,6 +30,13 @@ var (
     ssh://[user@]hostname[:port][/path] (will obtain socket path from service, if not given.)
     tcp://hostname:port (not secured)
     unix://path (absolute path required)
+    https://hostname[:port] (Podman API behind a TLS-terminating reverse proxy)
+    wss://hostname[:port] (as above, over a websocket)
+
+https:// and wss:// destinations accept --tls-ca, --tls-cert, --tls-key, --tls-verify,
+and --bearer-token/--bearer-token-file to authenticate against the remote API.
+
+By default the destination is dialed before it is saved; pass --no-verify to skip the check.
 `,
 		RunE:              add,
 		ValidArgsFunction: completion.AutocompleteNone,
@@ -50,11 +59,20 @@ var (
 	dockerPath string
 
 	cOpts = struct {
-		Identity string
-		Port     int
-		UDSPath  string
-		Default  bool
-	}{}
+		Identity        string
+		Port            int
+		UDSPath         string
+		Default         bool
+		NoVerify        bool
+		TLSCAPath       string
+		TLSCertPath     string
+		TLSKeyPath      string
+		TLSVerify       bool
+		BearerToken     string
+		BearerTokenFile string
+	}{
+		TLSVerify: true,
+	}
 )
 
 func init() {
@@ -78,6 +96,30 @@ func init() {
 
 	flags.BoolVarP(&cOpts.Default, "default", "d", false, "Set connection to be default")
 
+	flags.BoolVar(&cOpts.NoVerify, "no-verify", false, "Skip testing the destination is reachable before persisting it")
+
+	tlsCAFlagName := "tls-ca"
+	flags.StringVar(&cOpts.TLSCAPath, tlsCAFlagName, "", "path to CA certificate used to verify the destination (https:// and wss:// only)")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsCAFlagName, completion.AutocompleteDefault)
+
+	tlsCertFlagName := "tls-cert"
+	flags.StringVar(&cOpts.TLSCertPath, tlsCertFlagName, "", "path to client certificate for mutual TLS (https:// and wss:// only)")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsCertFlagName, completion.AutocompleteDefault)
+
+	tlsKeyFlagName := "tls-key"
+	flags.StringVar(&cOpts.TLSKeyPath, tlsKeyFlagName, "", "path to client key for mutual TLS (https:// and wss:// only)")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsKeyFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&cOpts.TLSVerify, "tls-verify", true, "require TLS verification of the destination (https:// and wss:// only)")
+
+	bearerTokenFlagName := "bearer-token"
+	flags.StringVar(&cOpts.BearerToken, bearerTokenFlagName, "", "bearer token sent as the Authorization header (https:// and wss:// only)")
+	_ = addCmd.RegisterFlagCompletionFunc(bearerTokenFlagName, completion.AutocompleteNone)
+
+	bearerTokenFileFlagName := "bearer-token-file"
+	flags.StringVar(&cOpts.BearerTokenFile, bearerTokenFileFlagName, "", "path to a file containing the bearer token (https:// and wss:// only)")
+	_ = addCmd.RegisterFlagCompletionFunc(bearerTokenFileFlagName, completion.AutocompleteDefault)
+
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: createCmd,
 		Parent:  system.ContextCmd,
@@ -133,6 +175,11 @@ func add(cmd *cobra.Command, args []string) error {
 
 	switch uri.Scheme {
 	case "ssh":
+		if !cOpts.NoVerify {
+			if err := verify(entities, uri, sshMode, nil); err != nil {
+				return fmt.Errorf("connection test failed (use --no-verify to skip): %w", err)
+			}
+		}
 		return ssh.Create(entities, sshMode)
 	case "unix":
 		if cmd.Flags().Changed("identity") {
@@ -164,19 +211,26 @@ func add(cmd *cobra.Command, args []string) error {
 		if uri.Port() == "" {
 			return errors.New("tcp scheme requires a port either via --port or in destination URL")
 		}
+	case "https", "wss":
+		if cmd.Flags().Changed("socket-path") {
+			return fmt.Errorf("--socket-path option not supported for %s scheme", uri.Scheme)
+		}
+		if cmd.Flags().Changed("identity") {
+			return fmt.Errorf("--identity option not supported for %s scheme", uri.Scheme)
+		}
+		if cOpts.BearerToken != "" && cOpts.BearerTokenFile != "" {
+			return errors.New("--bearer-token and --bearer-token-file are mutually exclusive")
+		}
 	default:
 		logrus.Warnf("%q unknown scheme, no validation provided", uri.Scheme)
 	}
 
-	cfg, err := config.ReadCustomConfig()
-	if err != nil {
-		return err
-	}
-
-	if cmd.Flags().Changed("default") {
-		if cOpts.Default {
-			cfg.Engine.ActiveService = args[0]
+	if cOpts.BearerTokenFile != "" {
+		token, err := os.ReadFile(cOpts.BearerTokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read --bearer-token-file: %w", err)
 		}
+		cOpts.BearerToken = strings.TrimSpace(string(token))
 	}
 
 	dst := config.Destination{
@@ -187,6 +241,49 @@ func add(cmd *cobra.Command, args []string) error {
 		dst.Identity = cOpts.Identity
 	}
 
+	if uri.Scheme == "https" || uri.Scheme == "wss" {
+		if cOpts.TLSCAPath != "" {
+			expanded, err := validateTLSFile("ca", cOpts.TLSCAPath)
+			if err != nil {
+				return err
+			}
+			dst.CACertPath = expanded
+		}
+		if cOpts.TLSCertPath != "" {
+			expanded, err := validateTLSFile("cert", cOpts.TLSCertPath)
+			if err != nil {
+				return err
+			}
+			dst.CertPath = expanded
+		}
+		if cOpts.TLSKeyPath != "" {
+			expanded, err := validateTLSFile("key", cOpts.TLSKeyPath)
+			if err != nil {
+				return err
+			}
+			dst.KeyPath = expanded
+		}
+		dst.TLSVerify = cOpts.TLSVerify
+		dst.BearerToken = cOpts.BearerToken
+	}
+
+	if !cOpts.NoVerify {
+		if err := verify(entities, uri, sshMode, &dst); err != nil {
+			return fmt.Errorf("connection test failed (use --no-verify to skip): %w", err)
+		}
+	}
+
+	cfg, err := config.ReadCustomConfig()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("default") {
+		if cOpts.Default {
+			cfg.Engine.ActiveService = args[0]
+		}
+	}
+
 	if cfg.Engine.ServiceDestinations == nil {
 		cfg.Engine.ServiceDestinations = map[string]config.Destination{
 			args[0]: dst,
@@ -199,10 +296,11 @@ func add(cmd *cobra.Command, args []string) error {
 }
 
 func create(cmd *cobra.Command, args []string) error {
-	dest, err := translateDest(dockerPath)
+	docker, err := translateDest(dockerPath)
 	if err != nil {
 		return err
 	}
+	dest := docker.host
 	if match, err := regexp.MatchString("^[A-Za-z][A-Za-z0-9+.-]*://", dest); err != nil {
 		return fmt.Errorf("invalid destination: %w", err)
 	} else if !match {
@@ -219,8 +317,18 @@ func create(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// As in import, a tcp:// host given TLS material is saved as https:// so
+	// podman's scheme-based dispatch presents that material when dialing it.
+	if uri.Scheme == "tcp" && (docker.caCertPath != "" || docker.certPath != "" || docker.keyPath != "") {
+		uri.Scheme = "https"
+	}
+
 	dst := config.Destination{
-		URI: uri.String(),
+		URI:        uri.String(),
+		CACertPath: docker.caCertPath,
+		CertPath:   docker.certPath,
+		KeyPath:    docker.keyPath,
+		TLSVerify:  docker.tlsVerify,
 	}
 
 	if cfg.Engine.ServiceDestinations == nil {
@@ -234,22 +342,80 @@ func create(cmd *cobra.Command, args []string) error {
 	return cfg.Write()
 }
 
-func translateDest(path string) (string, error) {
+// dockerDestination holds the pieces of a `--docker host=...` compound
+// option once parsed, mirroring the TLS material docker context create
+// accepts so podman can dial the same endpoints.
+type dockerDestination struct {
+	host       string
+	caCertPath string
+	certPath   string
+	keyPath    string
+	tlsVerify  bool
+}
+
+func translateDest(path string) (*dockerDestination, error) {
 	if path == "" {
-		return "", nil
+		return &dockerDestination{tlsVerify: true}, nil
 	}
 	split := strings.SplitN(path, "=", 2)
 	if len(split) == 1 {
-		return split[0], nil
+		return &dockerDestination{host: split[0], tlsVerify: true}, nil
 	}
 	if split[0] != "host" {
-		return "", fmt.Errorf("\"host\" is requited for --docker option")
+		return nil, fmt.Errorf("\"host\" is requited for --docker option")
 	}
-	// "host=tcp://myserver:2376,ca=~/ca-file,cert=~/cert-file,key=~/key-file"
+	// "host=tcp://myserver:2376,ca=~/ca-file,cert=~/cert-file,key=~/key-file,skip-tls-verify=true"
 	vals := strings.Split(split[1], ",")
-	if len(vals) > 1 {
-		return "", fmt.Errorf("--docker additional options %q not supported", strings.Join(vals[1:], ","))
+	dest := &dockerDestination{host: vals[0], tlsVerify: true}
+	for _, kv := range vals[1:] {
+		opt := strings.SplitN(kv, "=", 2)
+		if len(opt) != 2 {
+			return nil, fmt.Errorf("invalid --docker option %q: expected key=value", kv)
+		}
+		key, val := opt[0], opt[1]
+		switch key {
+		case "ca":
+			expanded, err := validateTLSFile("ca", val)
+			if err != nil {
+				return nil, err
+			}
+			dest.caCertPath = expanded
+		case "cert":
+			expanded, err := validateTLSFile("cert", val)
+			if err != nil {
+				return nil, err
+			}
+			dest.certPath = expanded
+		case "key":
+			expanded, err := validateTLSFile("key", val)
+			if err != nil {
+				return nil, err
+			}
+			dest.keyPath = expanded
+		case "skip-tls-verify":
+			skip, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --docker option %q: %w", kv, err)
+			}
+			dest.tlsVerify = !skip
+		default:
+			return nil, fmt.Errorf("--docker option %q not supported", key)
+		}
+	}
+	return dest, nil
+}
+
+// validateTLSFile expands path and confirms the referenced TLS material
+// exists and is readable before it is persisted to containers.conf.
+func validateTLSFile(kind, path string) (string, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s path %q: %w", kind, path, err)
+	}
+	f, err := os.Open(expanded)
+	if err != nil {
+		return "", fmt.Errorf("%s file %q: %w", kind, expanded, err)
 	}
-	// for now we ignore other fields specified on command line
-	return vals[0], nil
+	f.Close()
+	return expanded, nil
 }
@@ -0,0 +1,84 @@
+package connection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTranslateDest(t *testing.T) {
+	dir := t.TempDir()
+	ca := filepath.Join(dir, "ca.pem")
+	cert := filepath.Join(dir, "cert.pem")
+	key := filepath.Join(dir, "key.pem")
+	for _, f := range []string{ca, cert, key} {
+		if err := os.WriteFile(f, []byte("test"), 0o600); err != nil {
+			t.Fatalf("failed to write %q: %v", f, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantHost   string
+		wantCA     string
+		wantCert   string
+		wantKey    string
+		wantVerify bool
+		wantErr    bool
+	}{
+		{name: "empty", path: "", wantHost: "", wantVerify: true},
+		{name: "bare host, no host= prefix", path: "myserver", wantHost: "myserver", wantVerify: true},
+		{name: "host only", path: "host=tcp://myserver:2376", wantHost: "tcp://myserver:2376", wantVerify: true},
+		{
+			name:       "host with TLS material",
+			path:       "host=tcp://myserver:2376,ca=" + ca + ",cert=" + cert + ",key=" + key,
+			wantHost:   "tcp://myserver:2376",
+			wantCA:     ca,
+			wantCert:   cert,
+			wantKey:    key,
+			wantVerify: true,
+		},
+		{
+			name:       "skip-tls-verify",
+			path:       "host=tcp://myserver:2376,skip-tls-verify=true",
+			wantHost:   "tcp://myserver:2376",
+			wantVerify: false,
+		},
+		{name: "missing host= prefix", path: "foo=bar", wantErr: true},
+		{name: "unsupported option", path: "host=tcp://myserver:2376,bogus=1", wantErr: true},
+		{name: "invalid key=value", path: "host=tcp://myserver:2376,novalue", wantErr: true},
+		{name: "invalid skip-tls-verify", path: "host=tcp://myserver:2376,skip-tls-verify=maybe", wantErr: true},
+		{name: "missing ca file", path: "host=tcp://myserver:2376,ca=" + filepath.Join(dir, "missing.pem"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := translateDest(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("translateDest(%q) expected an error, got none", tt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("translateDest(%q) returned unexpected error: %v", tt.path, err)
+			}
+			if got.host != tt.wantHost {
+				t.Errorf("host = %q, want %q", got.host, tt.wantHost)
+			}
+			if got.caCertPath != tt.wantCA {
+				t.Errorf("caCertPath = %q, want %q", got.caCertPath, tt.wantCA)
+			}
+			if got.certPath != tt.wantCert {
+				t.Errorf("certPath = %q, want %q", got.certPath, tt.wantCert)
+			}
+			if got.keyPath != tt.wantKey {
+				t.Errorf("keyPath = %q, want %q", got.keyPath, tt.wantKey)
+			}
+			if got.tlsVerify != tt.wantVerify {
+				t.Errorf("tlsVerify = %v, want %v", got.tlsVerify, tt.wantVerify)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package connection
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/system"
+	"github.com/containers/storage/pkg/homedir"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importCmd = &cobra.Command{
+		Use:               "import [options]",
+		Args:              cobra.NoArgs,
+		Short:             "Import destinations from a Docker contexts store",
+		Long:              `Read a Docker CLI contexts store and record the contexts it holds as Podman connections.`,
+		RunE:              connectionImport,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system connection import
+  podman system connection import --file ~/.docker/contexts
+  podman system connection import --dry-run --overwrite`,
+	}
+
+	importOpts = struct {
+		From      string
+		File      string
+		DryRun    bool
+		Overwrite bool
+	}{
+		From: "docker",
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: importCmd,
+		Parent:  system.ConnectionCmd,
+	})
+	flags := importCmd.Flags()
+
+	fromFlagName := "from"
+	flags.StringVar(&importOpts.From, fromFlagName, "docker", "contexts store to import from")
+	_ = importCmd.RegisterFlagCompletionFunc(fromFlagName, completion.AutocompleteNone)
+
+	fileFlagName := "file"
+	flags.StringVar(&importOpts.File, fileFlagName, "", `path to the contexts store (default "~/.docker/contexts")`)
+	_ = importCmd.RegisterFlagCompletionFunc(fileFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&importOpts.DryRun, "dry-run", false, "print the resulting destinations as JSON instead of writing them")
+	flags.BoolVar(&importOpts.Overwrite, "overwrite", false, "replace existing connections with the same name")
+}
+
+// dockerContextMeta mirrors the subset of a Docker CLI context's meta.json
+// that is relevant to reaching its "docker" endpoint.
+type dockerContextMeta struct {
+	Name      string `json:"Name"`
+	Endpoints struct {
+		Docker struct {
+			Host          string `json:"Host"`
+			SkipTLSVerify bool   `json:"SkipTLSVerify"`
+		} `json:"docker"`
+	} `json:"Endpoints"`
+}
+
+func connectionImport(cmd *cobra.Command, args []string) error {
+	if importOpts.From != "docker" {
+		return fmt.Errorf("--from %q not supported, only \"docker\" contexts can currently be imported", importOpts.From)
+	}
+
+	store := importOpts.File
+	if store == "" {
+		home, err := homedir.Home()
+		if err != nil {
+			return err
+		}
+		store = filepath.Join(home, ".docker", "contexts")
+	}
+
+	metas, err := filepath.Glob(filepath.Join(store, "meta", "*", "meta.json"))
+	if err != nil {
+		return err
+	}
+
+	destinations := make(map[string]config.Destination, len(metas))
+	for _, metaPath := range metas {
+		name, dst, err := readDockerContext(store, metaPath)
+		if err != nil {
+			return fmt.Errorf("failed to import %q: %w", metaPath, err)
+		}
+		if name == "" {
+			continue
+		}
+		destinations[name] = *dst
+	}
+
+	if importOpts.DryRun {
+		out, err := json.MarshalIndent(destinations, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	cfg, err := config.ReadCustomConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Engine.ServiceDestinations == nil {
+		cfg.Engine.ServiceDestinations = make(map[string]config.Destination, len(destinations))
+	}
+
+	if !importOpts.Overwrite {
+		for name := range destinations {
+			if _, found := cfg.Engine.ServiceDestinations[name]; found {
+				return fmt.Errorf("connection %q already exists, use --overwrite to replace it", name)
+			}
+		}
+	}
+
+	for name, dst := range destinations {
+		cfg.Engine.ServiceDestinations[name] = dst
+	}
+
+	return cfg.Write()
+}
+
+// readDockerContext loads a single Docker context's meta.json and copies any
+// TLS material it references into containers' own certs.d, returning the
+// context name and the equivalent Destination. A context with no "docker"
+// endpoint (e.g. a Kubernetes-only context) is skipped by returning "".
+func readDockerContext(store, metaPath string) (string, *config.Destination, error) {
+	raw, err := os.ReadFile(metaPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var meta dockerContextMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return "", nil, err
+	}
+	if meta.Endpoints.Docker.Host == "" {
+		return "", nil, nil
+	}
+
+	name, err := sanitizeContextName(meta.Name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	endpoint, err := url.Parse(meta.Endpoints.Docker.Host)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid docker endpoint %q: %w", meta.Endpoints.Docker.Host, err)
+	}
+
+	dst := &config.Destination{
+		TLSVerify: !meta.Endpoints.Docker.SkipTLSVerify,
+	}
+
+	// Docker keys its TLS material by the sha256 of the context name, not the name itself.
+	contextDigest := sha256.Sum256([]byte(meta.Name))
+	tlsDir := filepath.Join(store, "tls", hex.EncodeToString(contextDigest[:]), "docker")
+	destDir := filepath.Join(certsDir(), name)
+
+	for _, material := range []struct {
+		file string
+		dst  *string
+	}{
+		{"ca.pem", &dst.CACertPath},
+		{"cert.pem", &dst.CertPath},
+		{"key.pem", &dst.KeyPath},
+	} {
+		copied, err := copyIfExists(filepath.Join(tlsDir, material.file), filepath.Join(destDir, material.file))
+		if err != nil {
+			return "", nil, err
+		}
+		*material.dst = copied
+	}
+
+	// Docker contexts record a TLS-secured docker endpoint as tcp://host:2376
+	// and imply TLS from the presence of tls/ material rather than the
+	// scheme; translate that to https:// so podman's own scheme-based
+	// dispatch actually presents the certificates we just copied.
+	if endpoint.Scheme == "tcp" && (dst.CACertPath != "" || dst.CertPath != "" || dst.KeyPath != "") {
+		endpoint.Scheme = "https"
+	}
+	dst.URI = endpoint.String()
+
+	return name, dst, nil
+}
+
+// sanitizeContextName rejects a Docker context Name that isn't safe to use
+// as a single path component, so a crafted or corrupted meta.json (the
+// store may come from --file, an arbitrary path) can't write certificate
+// material outside certs.d via "../" or an absolute-looking name.
+func sanitizeContextName(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("context meta.json has an empty Name")
+	}
+	if clean := filepath.Base(filepath.Clean(name)); clean != name || clean == "." || clean == ".." {
+		return "", fmt.Errorf("unsafe context name %q", name)
+	}
+	return name, nil
+}
+
+// certsDir mirrors the containers.conf convention for where TLS material
+// referenced by a Destination is expected to live.
+func certsDir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "containers", "certs.d")
+	}
+	home, err := homedir.Home()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "containers", "certs.d")
+	}
+	return filepath.Join(home, ".config", "containers", "certs.d")
+}
+
+// copyIfExists copies src to dst, creating dst's directory as needed, and
+// returns dst. A missing src is not an error: it returns "" so the caller
+// can leave the corresponding Destination field empty.
+func copyIfExists(src, dst string) (string, error) {
+	in, err := os.Open(src)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return "", err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return "", err
+	}
+
+	return dst, nil
+}
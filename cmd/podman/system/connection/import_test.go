@@ -0,0 +1,38 @@
+package connection
+
+import "testing"
+
+func TestSanitizeContextName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "plain name", input: "laptop", wantErr: false},
+		{name: "empty name", input: "", wantErr: true},
+		{name: "dot", input: ".", wantErr: true},
+		{name: "dot dot", input: "..", wantErr: true},
+		{name: "relative traversal", input: "../../etc/passwd", wantErr: true},
+		{name: "embedded traversal", input: "foo/../../bar", wantErr: true},
+		{name: "nested path", input: "foo/bar", wantErr: true},
+		{name: "absolute path", input: "/etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeContextName(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("sanitizeContextName(%q) = %q, expected an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sanitizeContextName(%q) returned unexpected error: %v", tt.input, err)
+			}
+			if got != tt.input {
+				t.Errorf("sanitizeContextName(%q) = %q, want %q", tt.input, got, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/ssh"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/system"
+	"github.com/containers/podman/v4/pkg/bindings"
+	bindingsSystem "github.com/containers/podman/v4/pkg/bindings/system"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testCmd = &cobra.Command{
+		Use:               "test NAME",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Test destination for the Podman service",
+		Long:              `Dial a previously added destination and report whether, and at which stage, it failed.`,
+		RunE:              connectionTest,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system connection test laptop
+  podman system connection test production`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: testCmd,
+		Parent:  system.ConnectionCmd,
+	})
+}
+
+func connectionTest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.ReadCustomConfig()
+	if err != nil {
+		return err
+	}
+
+	dst, found := cfg.Engine.ServiceDestinations[args[0]]
+	if !found {
+		return fmt.Errorf("%q destination not found", args[0])
+	}
+
+	uri, err := url.Parse(dst.URI)
+	if err != nil {
+		return err
+	}
+
+	containerConfig := registry.PodmanConfig()
+	sshMode := ssh.DefineMode(containerConfig.SSHMode)
+	if sshMode == ssh.InvalidMode {
+		return fmt.Errorf("invalid ssh mode")
+	}
+
+	entities := &ssh.ConnectionCreateOptions{
+		Path:     dst.URI,
+		Identity: dst.Identity,
+	}
+
+	if err := verify(entities, uri, sshMode, &dst); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s) is reachable\n", args[0], dst.URI)
+	return nil
+}
+
+// connectionStage identifies which leg of dialing a destination failed, so
+// callers can react to the specific failure (DNS, TCP, SSH auth, a missing
+// socket, or an API mismatch) instead of a generic error string.
+type connectionStage string
+
+const (
+	stageDNS    connectionStage = "dns"
+	stageTCP    connectionStage = "tcp"
+	stageSSH    connectionStage = "ssh-auth"
+	stageSocket connectionStage = "socket"
+	stageAPI    connectionStage = "api"
+)
+
+type connectionError struct {
+	Stage connectionStage
+	Err   error
+}
+
+func (e *connectionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Stage, e.Err)
+}
+
+func (e *connectionError) Unwrap() error {
+	return e.Err
+}
+
+// verify dials uri and confirms a Podman service is actually listening there.
+// It is used both by `connection add`, to keep misconfigured hosts out of
+// containers.conf, and by `connection test` against an already saved entry.
+// dst carries the TLS/bearer-token material for https:// and wss://
+// destinations; it is ignored by the other schemes and may be nil.
+func verify(entities *ssh.ConnectionCreateOptions, uri *url.URL, sshMode ssh.EngineMode, dst *config.Destination) error {
+	switch uri.Scheme {
+	case "ssh":
+		return verifySSH(entities, uri, sshMode)
+	case "unix":
+		return verifyUnix(uri)
+	case "tcp":
+		return verifyTCP(uri)
+	case "https", "wss":
+		return verifyTLS(uri, dst)
+	default:
+		return nil
+	}
+}
+
+func verifySSH(entities *ssh.ConnectionCreateOptions, uri *url.URL, sshMode ssh.EngineMode) error {
+	if _, err := net.LookupHost(uri.Hostname()); err != nil {
+		return &connectionError{Stage: stageDNS, Err: err}
+	}
+
+	socketPath := uri.Path
+	if socketPath == "" {
+		socketPath = entities.Socket
+	}
+
+	if _, err := ssh.Exec(entities, sshMode, "true"); err != nil {
+		return &connectionError{Stage: stageSSH, Err: err}
+	}
+
+	if socketPath == "" {
+		// No remote socket path is known yet (e.g. `connection add host` with
+		// no path and no --socket-path): ssh.Create negotiates one with the
+		// service itself, so here we only confirm the transport and auth work.
+		return nil
+	}
+
+	if _, err := ssh.Exec(entities, sshMode, "test", "-S", socketPath); err != nil {
+		return &connectionError{Stage: stageSocket, Err: err}
+	}
+
+	return nil
+}
+
+func verifyUnix(uri *url.URL) error {
+	info, err := os.Stat(uri.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return &connectionError{Stage: stageSocket, Err: err}
+	case err != nil:
+		return &connectionError{Stage: stageSocket, Err: err}
+	case info.Mode()&os.ModeSocket == 0:
+		return &connectionError{Stage: stageSocket, Err: fmt.Errorf("%q is not a unix domain socket", uri.Path)}
+	}
+
+	return pingAPI(uri.String(), "")
+}
+
+func verifyTCP(uri *url.URL) error {
+	if _, err := net.LookupHost(uri.Hostname()); err != nil {
+		return &connectionError{Stage: stageDNS, Err: err}
+	}
+
+	conn, err := net.DialTimeout("tcp", uri.Host, 5*time.Second)
+	if err != nil {
+		return &connectionError{Stage: stageTCP, Err: err}
+	}
+	conn.Close()
+
+	return pingAPI(uri.String(), "")
+}
+
+// verifyTLS dials an https:// or wss:// destination, then pings its libpod
+// API using the same TLS config and bearer token
+// bindings.HTTPClientForDestination gives the bindings layer, so a failure
+// here means a real `podman --context=NAME ...` call would fail the same way.
+func verifyTLS(uri *url.URL, dst *config.Destination) error {
+	if _, err := net.LookupHost(uri.Hostname()); err != nil {
+		return &connectionError{Stage: stageDNS, Err: err}
+	}
+
+	host := uri.Host
+	if uri.Port() == "" {
+		host = net.JoinHostPort(uri.Hostname(), "443")
+	}
+
+	conn, err := net.DialTimeout("tcp", host, 5*time.Second)
+	if err != nil {
+		return &connectionError{Stage: stageTCP, Err: err}
+	}
+	conn.Close()
+
+	return pingAPIHTTPS(uri, dst)
+}
+
+func pingAPI(uri string, identity string) error {
+	ctx, err := bindings.NewConnectionWithIdentity(context.Background(), uri, identity, false)
+	if err != nil {
+		return &connectionError{Stage: stageAPI, Err: err}
+	}
+
+	if _, err := bindingsSystem.Ping(ctx); err != nil {
+		return &connectionError{Stage: stageAPI, Err: err}
+	}
+
+	return nil
+}
+
+// pingAPIHTTPS pings the libpod API of an https:// or wss:// destination
+// using the same TLS config and Authorization: Bearer header
+// bindings.HTTPClientForDestination builds for real API calls against it.
+func pingAPIHTTPS(uri *url.URL, dst *config.Destination) error {
+	if dst == nil {
+		dst = &config.Destination{TLSVerify: true}
+	}
+
+	client, err := bindings.HTTPClientForDestination(dst)
+	if err != nil {
+		return &connectionError{Stage: stageAPI, Err: err}
+	}
+
+	pingURL := *uri
+	if pingURL.Scheme == "wss" {
+		pingURL.Scheme = "https"
+	}
+	pingURL.Path = "/_ping"
+
+	req, err := http.NewRequest(http.MethodGet, pingURL.String(), nil)
+	if err != nil {
+		return &connectionError{Stage: stageAPI, Err: err}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &connectionError{Stage: stageAPI, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &connectionError{Stage: stageAPI, Err: fmt.Errorf("unexpected status %s", resp.Status)}
+	}
+
+	return nil
+}
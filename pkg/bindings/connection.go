@@ -0,0 +1,92 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/containers/common/pkg/config"
+)
+
+// Connection is the URI and HTTP client a context.Context produced by
+// NewConnectionWithIdentity carries through to the generated bindings (e.g.
+// bindings/system.Ping), so API calls made against that context reach the
+// right destination using the right transport.
+type Connection struct {
+	URI    *url.URL
+	Client *http.Client
+}
+
+type connectionKey struct{}
+
+// GetClient returns the Connection stashed in ctx by NewConnectionWithIdentity.
+func GetClient(ctx context.Context) *Connection {
+	if conn, ok := ctx.Value(connectionKey{}).(*Connection); ok {
+		return conn
+	}
+	return &Connection{Client: &http.Client{}}
+}
+
+// NewConnectionWithIdentity parses uri and returns a context carrying the
+// Connection that subsequent bindings calls (e.g. bindings/system.Ping) read
+// their *http.Client from; it does not dial uri itself. identity (an ssh
+// private key path) and machine (whether the connection is a `podman machine`
+// VM) are not used for any scheme. For https:// and wss:// destinations, the
+// client is built via HTTPClientForDestination from the matching
+// containers.conf entry's TLS and bearer-token material, the same
+// configuration connection add/test already verified the destination with.
+// unix:// destinations get a client that dials the socket at the URI's path;
+// every other scheme gets a default *http.Client.
+func NewConnectionWithIdentity(ctx context.Context, uri string, identity string, machine bool) (context.Context, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection URI %q: %w", uri, err)
+	}
+
+	var client *http.Client
+	switch parsed.Scheme {
+	case "https", "wss":
+		dst, err := destinationForURI(uri)
+		if err != nil {
+			return nil, err
+		}
+		client, err = HTTPClientForDestination(dst)
+		if err != nil {
+			return nil, err
+		}
+	case "unix":
+		socketPath := parsed.Path
+		client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+				},
+			},
+		}
+	default:
+		client = &http.Client{}
+	}
+
+	conn := &Connection{URI: parsed, Client: client}
+	return context.WithValue(ctx, connectionKey{}, conn), nil
+}
+
+// destinationForURI looks up the containers.conf Destination whose URI
+// matches uri, so an https:// or wss:// connection picks up the TLS and
+// bearer-token material `connection add`/`connection import` recorded for it.
+func destinationForURI(uri string) (*config.Destination, error) {
+	cfg, err := config.ReadCustomConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dst := range cfg.Engine.ServiceDestinations {
+		if dst.URI == uri {
+			return &dst, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no saved connection matches %q, add it with `podman system connection add` first", uri)
+}
@@ -0,0 +1,75 @@
+package bindings
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/containers/common/pkg/config"
+)
+
+// HTTPClientForDestination builds the *http.Client an https:// or wss://
+// Destination should use: its CA/client-certificate material feeds the TLS
+// config, and its bearer token, if any, is attached to every request's
+// Authorization header. NewConnectionWithIdentity calls this for the
+// "https" and "wss" schemes so `podman --context=NAME ...` authenticates
+// the same way `connection add`/`connection test` already verified it would.
+func HTTPClientForDestination(dst *config.Destination) (*http.Client, error) {
+	tlsConfig, err := tlsConfigForDestination(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := bearerTransport(dst.BearerToken, &http.Transport{TLSClientConfig: tlsConfig})
+	return &http.Client{Transport: transport}, nil
+}
+
+func tlsConfigForDestination(dst *config.Destination) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !dst.TLSVerify, //nolint:gosec // only false when verification was explicitly disabled (--tls-verify=false, skip-tls-verify, or a Docker context's SkipTLSVerify)
+	}
+
+	if dst.CACertPath != "" {
+		pem, err := os.ReadFile(dst.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate %q: %w", dst.CACertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", dst.CACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if dst.CertPath != "" || dst.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(dst.CertPath, dst.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// bearerRoundTripper attaches a fixed bearer token to every outgoing
+// request's Authorization header before handing it to next.
+type bearerRoundTripper struct {
+	token string
+	next  http.RoundTripper
+}
+
+func bearerTransport(token string, next http.RoundTripper) http.RoundTripper {
+	if token == "" {
+		return next
+	}
+	return &bearerRoundTripper{token: token, next: next}
+}
+
+func (b *bearerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return b.next.RoundTrip(req)
+}
@@ -0,0 +1,133 @@
+package bindings
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/common/pkg/config"
+)
+
+// writeSelfSignedPair generates a throwaway self-signed certificate/key pair
+// under dir and returns their PEM file paths.
+func writeSelfSignedPair(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bindings-test"},
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Unix(0, 0).Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+
+	certPath = filepath.Join(dir, "cert.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("writing cert: %v", err)
+	}
+
+	keyPath = filepath.Join(dir, "key.pem")
+	keyDER := x509.MarshalPKCS1PrivateKey(key)
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+
+	return certPath, keyPath
+}
+
+func TestTLSConfigForDestination(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeSelfSignedPair(t, dir)
+
+	caPath := filepath.Join(dir, "ca.pem")
+	caPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatalf("reading generated cert: %v", err)
+	}
+	if err := os.WriteFile(caPath, caPEM, 0o600); err != nil {
+		t.Fatalf("writing ca: %v", err)
+	}
+
+	t.Run("defaults to verifying", func(t *testing.T) {
+		tlsConfig, err := tlsConfigForDestination(&config.Destination{TLSVerify: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tlsConfig.InsecureSkipVerify {
+			t.Error("InsecureSkipVerify = true, want false")
+		}
+	})
+
+	t.Run("tls verify disabled", func(t *testing.T) {
+		tlsConfig, err := tlsConfigForDestination(&config.Destination{TLSVerify: false})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !tlsConfig.InsecureSkipVerify {
+			t.Error("InsecureSkipVerify = false, want true")
+		}
+	})
+
+	t.Run("loads CA pool", func(t *testing.T) {
+		tlsConfig, err := tlsConfigForDestination(&config.Destination{TLSVerify: true, CACertPath: caPath})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tlsConfig.RootCAs == nil {
+			t.Error("RootCAs not set, want non-nil pool")
+		}
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		_, err := tlsConfigForDestination(&config.Destination{TLSVerify: true, CACertPath: filepath.Join(dir, "missing.pem")})
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("invalid CA contents", func(t *testing.T) {
+		badCA := filepath.Join(dir, "bad-ca.pem")
+		if err := os.WriteFile(badCA, []byte("not a certificate"), 0o600); err != nil {
+			t.Fatalf("writing bad ca: %v", err)
+		}
+		_, err := tlsConfigForDestination(&config.Destination{TLSVerify: true, CACertPath: badCA})
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("loads client certificate", func(t *testing.T) {
+		tlsConfig, err := tlsConfigForDestination(&config.Destination{TLSVerify: true, CertPath: certPath, KeyPath: keyPath})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tlsConfig.Certificates) != 1 {
+			t.Errorf("Certificates = %d, want 1", len(tlsConfig.Certificates))
+		}
+	})
+
+	t.Run("mismatched cert and key", func(t *testing.T) {
+		_, otherKeyPath := writeSelfSignedPair(t, t.TempDir())
+		_, err := tlsConfigForDestination(&config.Destination{TLSVerify: true, CertPath: certPath, KeyPath: otherKeyPath})
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}